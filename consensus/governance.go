@@ -0,0 +1,119 @@
+// Package consensus's governance functions are a library stub for a
+// setOwner(address)/setOwnerBps(uint16)/owner()/ownerBps() precompile at
+// GovernanceAddress: the storage layout, authorization rule, and getters
+// are all implemented here, but there is no EVM Run/selector dispatch
+// wiring them up yet. Whatever transaction-processing layer eventually
+// routes calls to GovernanceAddress must decode the selector, then call
+// SetOwner/SetOwnerBps with caller set to the authenticated transaction
+// sender (msg.sender) -- these functions trust the caller argument as
+// given and do not themselves verify it came from a real transaction.
+package consensus
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// GovernanceAddress is the fixed address the eventual precompile
+	// dispatch will live at, backing the owner address and owner bps
+	// governance slots. See the package doc comment above for the current
+	// (unwired) state of that dispatch.
+	GovernanceAddress = "0x0000000000000000000000000000000000F60E"
+
+	// GovernanceMultisigAddress may call the governance setters in
+	// addition to the current owner itself.
+	GovernanceMultisigAddress = "0x00000000000000000000000000000000009A5E"
+
+	// GenesisOwnerAddress is the owner address migrated into the
+	// governance slot at genesis.
+	GenesisOwnerAddress = "0x273Cac41cd1aA2845A5A15B5183a428eaB62E050"
+
+	// GenesisOwnerBps is the owner bps migrated into the governance slot
+	// at genesis (20%).
+	GenesisOwnerBps = 2000
+)
+
+// ownerSlot and ownerBpsSlot are the governance precompile's storage slots
+// for the live owner address and owner bps.
+var (
+	ownerSlot    = common.BigToHash(big.NewInt(0))
+	ownerBpsSlot = common.BigToHash(big.NewInt(1))
+)
+
+// GetGovernanceAddress returns the governance precompile address.
+func GetGovernanceAddress() common.Address {
+	return common.HexToAddress(GovernanceAddress)
+}
+
+// GetGovernanceMultisigAddress returns the configured governance multisig
+// address.
+func GetGovernanceMultisigAddress() common.Address {
+	return common.HexToAddress(GovernanceMultisigAddress)
+}
+
+// GetOwnerAddress reads the live owner address from the governance
+// precompile's storage. This address is administrative only: it (and the
+// governance multisig) is the sole caller authorized to invoke SetOwner
+// and SetOwnerBps. It does not itself receive block rewards; the owner's
+// share of every block reward is paid into TreasuryAddress and later
+// distributed to voters/stakers by the consensus/settlement package (see
+// ApplyBlockRewards).
+func GetOwnerAddress(state StateDB) common.Address {
+	return common.BytesToAddress(state.GetState(GetGovernanceAddress(), ownerSlot).Bytes())
+}
+
+// GetOwnerBps reads the live owner bps (basis points, 10000 = 100%) from
+// the governance precompile's storage.
+func GetOwnerBps(state StateDB) uint16 {
+	return uint16(new(big.Int).SetBytes(state.GetState(GetGovernanceAddress(), ownerBpsSlot).Bytes()).Uint64())
+}
+
+// InitGovernance migrates the genesis owner address and bps into the
+// governance precompile's storage. Call this once, when building genesis
+// state.
+func InitGovernance(state StateDB) {
+	setOwnerSlot(state, common.HexToAddress(GenesisOwnerAddress))
+	setOwnerBpsSlot(state, GenesisOwnerBps)
+}
+
+// SetOwner updates the governance owner address. Only the current owner or
+// the governance multisig may call this; the new address immediately
+// becomes the sole administrator authorized to call SetOwner/SetOwnerBps
+// going forward. It does not change where block rewards are paid (see
+// GetOwnerAddress). caller is trusted as given -- the eventual precompile
+// dispatch must populate it from the authenticated transaction sender
+// before calling SetOwner (see the package doc comment).
+func SetOwner(state StateDB, caller common.Address, newOwner common.Address) error {
+	if !isGovernanceCaller(state, caller) {
+		return errors.New("consensus: caller is not the owner or governance multisig")
+	}
+	setOwnerSlot(state, newOwner)
+	return nil
+}
+
+// SetOwnerBps updates the governance owner bps. Only the current owner or
+// the governance multisig may call this; the change is visible to the next
+// block's ApplyBlockRewards call. caller is trusted as given -- see the
+// package doc comment and SetOwner.
+func SetOwnerBps(state StateDB, caller common.Address, newOwnerBps uint16) error {
+	if !isGovernanceCaller(state, caller) {
+		return errors.New("consensus: caller is not the owner or governance multisig")
+	}
+	setOwnerBpsSlot(state, newOwnerBps)
+	return nil
+}
+
+func isGovernanceCaller(state StateDB, caller common.Address) bool {
+	return caller == GetOwnerAddress(state) || caller == GetGovernanceMultisigAddress()
+}
+
+func setOwnerSlot(state StateDB, owner common.Address) {
+	state.SetState(GetGovernanceAddress(), ownerSlot, common.BytesToHash(owner.Bytes()))
+}
+
+func setOwnerBpsSlot(state StateDB, ownerBps uint16) {
+	state.SetState(GetGovernanceAddress(), ownerBpsSlot, common.BigToHash(big.NewInt(int64(ownerBps))))
+}
@@ -0,0 +1,75 @@
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// RewardsTreasuryAddress holds the fixed, genesis-allocated balance that
+// RewardsTreasury vests linearly to miners, giving the chain a
+// deterministic total supply instead of unbounded halving-based minting.
+const RewardsTreasuryAddress = "0x000000000000000000000000000000000007EB"
+
+// RewardsTreasury vests a fixed pre-mined balance linearly over
+// VestingBlocks blocks starting at StartBlock. A ChainConfig with a nil
+// RewardsTreasury keeps the original unbounded halving-based minting.
+type RewardsTreasury struct {
+	Address common.Address
+
+	// TotalSupply is the full genesis-allocated balance this treasury
+	// vests over its lifetime.
+	TotalSupply *big.Int
+
+	// StartBlock is the block height at which vesting begins.
+	StartBlock uint64
+
+	// VestingBlocks is the number of blocks over which TotalSupply vests
+	// linearly.
+	VestingBlocks uint64
+}
+
+// NewRewardsTreasury returns a RewardsTreasury at RewardsTreasuryAddress
+// vesting totalSupply linearly over vestingBlocks blocks starting at
+// startBlock.
+func NewRewardsTreasury(totalSupply *big.Int, startBlock, vestingBlocks uint64) *RewardsTreasury {
+	return &RewardsTreasury{
+		Address:       common.HexToAddress(RewardsTreasuryAddress),
+		TotalSupply:   totalSupply,
+		StartBlock:    startBlock,
+		VestingBlocks: vestingBlocks,
+	}
+}
+
+// TreasuryBalance returns the amount still held in the treasury account,
+// i.e. not yet released to miners.
+func (rt *RewardsTreasury) TreasuryBalance(state StateDB) *big.Int {
+	return state.GetBalance(rt.Address)
+}
+
+// Vested returns the total amount that should have vested by blockNumber,
+// linear in (blockNumber - StartBlock) / VestingBlocks.
+func (rt *RewardsTreasury) Vested(blockNumber uint64) *big.Int {
+	if blockNumber <= rt.StartBlock {
+		return big.NewInt(0)
+	}
+
+	elapsed := blockNumber - rt.StartBlock
+	if elapsed >= rt.VestingBlocks {
+		return new(big.Int).Set(rt.TotalSupply)
+	}
+
+	vested := new(big.Int).Mul(rt.TotalSupply, new(big.Int).SetUint64(elapsed))
+	return vested.Div(vested, new(big.Int).SetUint64(rt.VestingBlocks))
+}
+
+// Remaining returns how much of the amount vested by blockNumber has not
+// yet been released from the treasury, i.e. how much is still payable.
+func (rt *RewardsTreasury) Remaining(state StateDB, blockNumber uint64) *big.Int {
+	alreadyReleased := new(big.Int).Sub(rt.TotalSupply, state.GetBalance(rt.Address))
+
+	remaining := new(big.Int).Sub(rt.Vested(blockNumber), alreadyReleased)
+	if remaining.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return remaining
+}
@@ -0,0 +1,141 @@
+// Package settlement distributes the block reward treasury accumulated by
+// consensus.ApplyBlockRewards to voters/stakers at the end of each epoch,
+// modeled after Vapor's SettlementReward.
+package settlement
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	".."
+)
+
+// EpochLength is the number of blocks between settlements (1,200 blocks).
+const EpochLength = 1200
+
+// DefaultRewardRatio is the share (in basis points, 10000 = 100%) of the
+// treasury pool that is distributed to voters; the remainder stays in the
+// treasury for the operator.
+const DefaultRewardRatio = 10000
+
+// Payout is a single voter's share of an epoch's settlement.
+type Payout struct {
+	Voter  common.Address
+	Amount *big.Int
+}
+
+// StateDB is the state access the settlement subsystem needs: balance
+// mutation to apply payouts, plus a view of the vote/stake weights
+// collected by scanning vote transactions between two block heights.
+type StateDB interface {
+	consensus.StateDB
+	VoteWeightsBetween(startHeight, endHeight uint64) (map[common.Address]uint64, error)
+}
+
+// EpochMemo is the JSON memo attached to a settlement transaction,
+// describing the epoch it settles and the node that computed it.
+type EpochMemo struct {
+	StartHeight uint64 `json:"startHeight"`
+	EndHeight   uint64 `json:"endHeight"`
+	NodePubKey  string `json:"nodePubKey"`
+}
+
+// Settlement computes and applies the vote-weighted distribution of the
+// treasury balance accumulated between startHeight and endHeight.
+type Settlement struct {
+	state       StateDB
+	startHeight uint64
+	endHeight   uint64
+
+	// RewardRatio is the share (in basis points, 10000 = 100%) of the
+	// treasury pool distributed to voters; the rest is left in the
+	// treasury for the operator.
+	RewardRatio uint16
+}
+
+// NewSettlement creates a Settlement covering the vote weights and treasury
+// balance observed between startHeight and endHeight.
+func NewSettlement(state StateDB, startHeight, endHeight uint64) *Settlement {
+	return &Settlement{
+		state:       state,
+		startHeight: startHeight,
+		endHeight:   endHeight,
+		RewardRatio: DefaultRewardRatio,
+	}
+}
+
+// Compute returns the list of voter payouts for this epoch: each voter
+// receives totalPool * voteWeight / totalVotes, where totalPool is the
+// RewardRatio share of the treasury balance.
+func (s *Settlement) Compute() ([]Payout, error) {
+	voteWeights, err := s.state.VoteWeightsBetween(s.startHeight, s.endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalVotes uint64
+	for _, weight := range voteWeights {
+		totalVotes += weight
+	}
+	if totalVotes == 0 {
+		return nil, nil
+	}
+
+	totalPool := new(big.Int).Mul(s.state.GetBalance(consensus.GetTreasuryAddress()), big.NewInt(int64(s.RewardRatio)))
+	totalPool.Div(totalPool, big.NewInt(10000))
+
+	// Sort voters for deterministic payout ordering across nodes.
+	voters := make([]common.Address, 0, len(voteWeights))
+	for voter := range voteWeights {
+		voters = append(voters, voter)
+	}
+	sort.Slice(voters, func(i, j int) bool {
+		return voters[i].Hex() < voters[j].Hex()
+	})
+
+	payouts := make([]Payout, 0, len(voters))
+	for _, voter := range voters {
+		amount := new(big.Int).Mul(totalPool, new(big.Int).SetUint64(voteWeights[voter]))
+		amount.Div(amount, new(big.Int).SetUint64(totalVotes))
+		if amount.Sign() == 0 {
+			continue
+		}
+		payouts = append(payouts, Payout{Voter: voter, Amount: amount})
+	}
+
+	return payouts, nil
+}
+
+// Apply computes this epoch's payouts and debits the treasury to credit
+// each voter in state.
+func (s *Settlement) Apply(state StateDB) error {
+	payouts, err := s.Compute()
+	if err != nil {
+		return err
+	}
+
+	treasuryAddr := consensus.GetTreasuryAddress()
+	for _, payout := range payouts {
+		if state.GetBalance(treasuryAddr).Cmp(payout.Amount) < 0 {
+			return errors.New("settlement: treasury balance insufficient for payout")
+		}
+		state.SubBalance(treasuryAddr, payout.Amount)
+		state.AddBalance(payout.Voter, payout.Amount)
+	}
+
+	return nil
+}
+
+// Memo builds the JSON memo describing this settlement's epoch range and
+// the node's public key, for attaching to the settlement transaction.
+func (s *Settlement) Memo(nodePubKey string) ([]byte, error) {
+	return json.Marshal(EpochMemo{
+		StartHeight: s.startHeight,
+		EndHeight:   s.endHeight,
+		NodePubKey:  nodePubKey,
+	})
+}
@@ -0,0 +1,162 @@
+package settlement
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	".."
+)
+
+// fakeState is a minimal StateDB backed by in-memory maps, used to drive
+// settlement tests without a real chain.
+type fakeState struct {
+	balances map[common.Address]*big.Int
+	storage  map[common.Address]map[common.Hash]common.Hash
+	votes    map[common.Address]uint64
+}
+
+func newFakeState() *fakeState {
+	return &fakeState{
+		balances: make(map[common.Address]*big.Int),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+		votes:    make(map[common.Address]uint64),
+	}
+}
+
+func (f *fakeState) GetState(addr common.Address, key common.Hash) common.Hash {
+	return f.storage[addr][key]
+}
+
+func (f *fakeState) SetState(addr common.Address, key common.Hash, value common.Hash) {
+	if f.storage[addr] == nil {
+		f.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	f.storage[addr][key] = value
+}
+
+func (f *fakeState) AddBalance(addr common.Address, amount *big.Int) {
+	bal, ok := f.balances[addr]
+	if !ok {
+		bal = big.NewInt(0)
+	}
+	f.balances[addr] = new(big.Int).Add(bal, amount)
+}
+
+func (f *fakeState) SubBalance(addr common.Address, amount *big.Int) {
+	bal, ok := f.balances[addr]
+	if !ok {
+		bal = big.NewInt(0)
+	}
+	f.balances[addr] = new(big.Int).Sub(bal, amount)
+}
+
+func (f *fakeState) GetBalance(addr common.Address) *big.Int {
+	if bal, ok := f.balances[addr]; ok {
+		return bal
+	}
+	return big.NewInt(0)
+}
+
+func (f *fakeState) SetBalance(addr common.Address, amount *big.Int) {
+	f.balances[addr] = amount
+}
+
+func (f *fakeState) VoteWeightsBetween(startHeight, endHeight uint64) (map[common.Address]uint64, error) {
+	return f.votes, nil
+}
+
+var (
+	voterA = common.HexToAddress("0x1000000000000000000000000000000000000a")
+	voterB = common.HexToAddress("0x1000000000000000000000000000000000000b")
+)
+
+func TestComputeSplitsPoolByVoteWeight(t *testing.T) {
+	state := newFakeState()
+	state.SetBalance(consensus.GetTreasuryAddress(), big.NewInt(1000))
+	state.votes[voterA] = 3
+	state.votes[voterB] = 1
+
+	s := NewSettlement(state, 0, EpochLength)
+	payouts, err := s.Compute()
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if len(payouts) != 2 {
+		t.Fatalf("len(payouts) = %v, want 2", len(payouts))
+	}
+	if payouts[0].Voter != voterA || payouts[0].Amount.Cmp(big.NewInt(750)) != 0 {
+		t.Errorf("payouts[0] = %+v, want voter %v amount 750", payouts[0], voterA)
+	}
+	if payouts[1].Voter != voterB || payouts[1].Amount.Cmp(big.NewInt(250)) != 0 {
+		t.Errorf("payouts[1] = %+v, want voter %v amount 250", payouts[1], voterB)
+	}
+}
+
+func TestComputeRewardRatioRetainsOperatorShare(t *testing.T) {
+	state := newFakeState()
+	state.SetBalance(consensus.GetTreasuryAddress(), big.NewInt(1000))
+	state.votes[voterA] = 1
+
+	s := NewSettlement(state, 0, EpochLength)
+	s.RewardRatio = 5000 // operator keeps half the pool
+
+	payouts, err := s.Compute()
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if len(payouts) != 1 || payouts[0].Amount.Cmp(big.NewInt(500)) != 0 {
+		t.Errorf("payouts = %+v, want single payout of 500", payouts)
+	}
+}
+
+func TestComputeNoVotesYieldsNoPayouts(t *testing.T) {
+	state := newFakeState()
+	state.SetBalance(consensus.GetTreasuryAddress(), big.NewInt(1000))
+
+	s := NewSettlement(state, 0, EpochLength)
+	payouts, err := s.Compute()
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if len(payouts) != 0 {
+		t.Errorf("payouts = %+v, want none", payouts)
+	}
+}
+
+func TestApplyDebitsTreasuryAndCreditsVoters(t *testing.T) {
+	state := newFakeState()
+	treasury := consensus.GetTreasuryAddress()
+	state.SetBalance(treasury, big.NewInt(1000))
+	state.votes[voterA] = 1
+	state.votes[voterB] = 1
+
+	s := NewSettlement(state, 0, EpochLength)
+	if err := s.Apply(state); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if got := state.GetBalance(treasury); got.Cmp(big.NewInt(0)) != 0 {
+		t.Errorf("treasury balance after Apply() = %v, want 0", got)
+	}
+	if got := state.GetBalance(voterA); got.Cmp(big.NewInt(500)) != 0 {
+		t.Errorf("voterA balance = %v, want 500", got)
+	}
+	if got := state.GetBalance(voterB); got.Cmp(big.NewInt(500)) != 0 {
+		t.Errorf("voterB balance = %v, want 500", got)
+	}
+}
+
+func TestMemoDescribesEpochRange(t *testing.T) {
+	s := NewSettlement(newFakeState(), 1200, 2400)
+	memo, err := s.Memo("node-pubkey")
+	if err != nil {
+		t.Fatalf("Memo() error = %v", err)
+	}
+
+	want := `{"startHeight":1200,"endHeight":2400,"nodePubKey":"node-pubkey"}`
+	if string(memo) != want {
+		t.Errorf("Memo() = %s, want %s", memo, want)
+	}
+}
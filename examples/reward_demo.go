@@ -5,40 +5,93 @@ import (
 	"math/big"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"../consensus"
 )
 
+// demoState is a minimal in-memory consensus.StateDB, standing in for a
+// real chain's state while demoing the reward split.
+type demoState struct {
+	balances map[common.Address]*big.Int
+	storage  map[common.Address]map[common.Hash]common.Hash
+}
+
+func newDemoState() *demoState {
+	return &demoState{
+		balances: make(map[common.Address]*big.Int),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+	}
+}
+
+func (d *demoState) AddBalance(addr common.Address, amount *big.Int) {
+	d.balances[addr] = new(big.Int).Add(d.GetBalance(addr), amount)
+}
+
+func (d *demoState) SubBalance(addr common.Address, amount *big.Int) {
+	d.balances[addr] = new(big.Int).Sub(d.GetBalance(addr), amount)
+}
+
+func (d *demoState) GetBalance(addr common.Address) *big.Int {
+	if bal, ok := d.balances[addr]; ok {
+		return bal
+	}
+	return big.NewInt(0)
+}
+
+func (d *demoState) SetBalance(addr common.Address, amount *big.Int) {
+	d.balances[addr] = amount
+}
+
+func (d *demoState) GetState(addr common.Address, key common.Hash) common.Hash {
+	return d.storage[addr][key]
+}
+
+func (d *demoState) SetState(addr common.Address, key common.Hash, value common.Hash) {
+	if d.storage[addr] == nil {
+		d.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	d.storage[addr][key] = value
+}
+
 func main() {
 	fmt.Println("VLY Blockchain Owner Reward System Demo")
 	fmt.Println("======================================")
 	fmt.Println()
 
+	chainConfig := consensus.DefaultChainConfig()
+
+	state := newDemoState()
+	consensus.InitGovernance(state)
+	ownerBps := consensus.GetOwnerBps(state)
+
 	// Owner address
-	fmt.Printf("Owner Address: %s\n", consensus.GetOwnerAddress())
+	fmt.Printf("Owner Address: %s\n", consensus.GetOwnerAddress(state))
+	fmt.Printf("Owner Share: %.2f%%\n", float64(ownerBps)/100)
 	fmt.Println()
 
 	// Demonstrate rewards at different block heights
 	testBlocks := []uint64{0, 50000, 100000, 210000, 420000, 630000, 840000}
 
 	fmt.Println("Block Reward Distribution:")
-	fmt.Printf("%-10s %-15s %-15s %-15s\n", "Block", "Total Reward", "Owner (20%)", "Miner (80%)")
+	fmt.Printf("%-10s %-15s %-15s %-15s\n", "Block", "Total Reward", "Owner", "Miner")
 	fmt.Println("---------------------------------------------------------------")
 
 	for _, blockHeight := range testBlocks {
-		totalReward := consensus.CalculateBlockReward(blockHeight)
-		ownerReward := consensus.CalculateOwnerReward(blockHeight)
-		minerReward := consensus.CalculateMinerReward(blockHeight)
+		totalReward := consensus.CalculateBlockReward(chainConfig, blockHeight)
+		ownerReward := consensus.CalculateOwnerReward(totalReward, ownerBps)
+		minerReward := consensus.CalculateMinerReward(totalReward, ownerBps)
 
 		// Convert from wei to VLY (divide by 10^18)
 		totalVLY := new(big.Float).Quo(new(big.Float).SetInt(totalReward), big.NewFloat(1e18))
 		ownerVLY := new(big.Float).Quo(new(big.Float).SetInt(ownerReward), big.NewFloat(1e18))
 		minerVLY := new(big.Float).Quo(new(big.Float).SetInt(minerReward), big.NewFloat(1e18))
 
-		fmt.Printf("%-10s %-15s %-15s %-15s\n", 
+		fmt.Printf("%-10s %-15s %-15s %-15s\n",
 			strconv.FormatUint(blockHeight, 10),
-			totalVLY.Text('f', 2) + " VLY",
-			ownerVLY.Text('f', 2) + " VLY",
-			minerVLY.Text('f', 2) + " VLY")
+			totalVLY.Text('f', 2)+" VLY",
+			ownerVLY.Text('f', 2)+" VLY",
+			minerVLY.Text('f', 2)+" VLY")
 	}
 
 	fmt.Println()
@@ -47,4 +100,4 @@ func main() {
 	fmt.Println("- Block 210,000-419,999: 25 VLY per block")
 	fmt.Println("- Block 420,000-629,999: 12.5 VLY per block")
 	fmt.Println("- And so on...")
-}
\ No newline at end of file
+}